@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store/fsstore"
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store/sqlitestore"
+)
+
+// defaultChecksumDir is where file-checksum-guard has always kept its flat
+// files; it's also where a pre-pkg/store install's checksums live, so
+// migrateIfNeeded looks here.
+const defaultChecksumDir = "/tmp/claude-file-checksums"
+
+// Config picks which store.Store backend the CLI and daemon use.
+type Config struct {
+	Store struct {
+		Backend string `toml:"backend"` // "fs" (default), "sqlite", or "workspace"
+		Path    string `toml:"path"`    // directory (fs) or database file (sqlite); ignored for workspace
+	} `toml:"store"`
+}
+
+// configPath returns $XDG_CONFIG_HOME/claude-fcg/config.toml, falling back
+// to ~/.config when XDG_CONFIG_HOME isn't set.
+func configPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "claude-fcg", "config.toml")
+}
+
+// loadConfig reads the TOML config file (if any), then lets FCG_STORE
+// override it, then fills in defaults for anything still unset.
+func loadConfig() Config {
+	var cfg Config
+	if p := configPath(); p != "" {
+		toml.DecodeFile(p, &cfg) // best-effort; missing/invalid config falls back to defaults
+	}
+	if env := os.Getenv("FCG_STORE"); env != "" {
+		applyStoreEnv(&cfg, env)
+	}
+	if cfg.Store.Backend == "" {
+		cfg.Store.Backend = "fs"
+	}
+	if cfg.Store.Path == "" && cfg.Store.Backend != "workspace" {
+		cfg.Store.Path = defaultChecksumDir
+	}
+	return cfg
+}
+
+// applyStoreEnv parses FCG_STORE values of the form "sqlite:///abs/path.db",
+// "fs:///abs/path/dir", or "workspace://" (no path; see openStore),
+// overriding whatever the config file set. A bare path with no
+// "scheme://" prefix just overrides Path, keeping whatever backend the
+// config file (or default) already chose.
+func applyStoreEnv(cfg *Config, env string) {
+	backend, path, ok := strings.Cut(env, "://")
+	if !ok {
+		cfg.Store.Path = env
+		return
+	}
+	cfg.Store.Backend = backend
+	// path is everything after the "://" separator as-is: for the
+	// conventional triple-slash absolute form ("sqlite:///abs/path.db") it
+	// already starts with the leading "/"; for a relative form
+	// ("fs://relative/dir") it doesn't. Either way it must not be mangled
+	// by re-prepending a slash.
+	cfg.Store.Path = path
+}
+
+// openStore constructs the store.Store backend cfg selected.
+func openStore(cfg Config) (store.Store, error) {
+	switch cfg.Store.Backend {
+	case "fs":
+		return fsstore.New(cfg.Store.Path), nil
+	case "sqlite":
+		return sqlitestore.Open(cfg.Store.Path)
+	case "workspace":
+		// Scoped to the current directory rather than cfg.Store.Path, so
+		// separate repos sharing this machine don't collide in one
+		// checksum dir the way the flat-file default under /tmp does.
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("resolve workspace store: %w", err)
+		}
+		return fsstore.New(filepath.Join(cwd, ".claude", "checksums")), nil
+	default:
+		return nil, fmt.Errorf("unknown store backend %q", cfg.Store.Backend)
+	}
+}
+
+// migrateIfNeeded copies over checksums recorded by a pre-existing
+// defaultChecksumDir fsstore the first time the CLI is pointed at a
+// non-default backend. It's a no-op once dst already has entries, so it
+// only ever runs once per fresh backend.
+func migrateIfNeeded(cfg Config, dst store.Store) {
+	if cfg.Store.Backend == "fs" && cfg.Store.Path == defaultChecksumDir {
+		return
+	}
+	if keys, err := dst.List(); err != nil || len(keys) > 0 {
+		return
+	}
+	if _, err := os.Stat(defaultChecksumDir); err != nil {
+		return
+	}
+	fsstore.New(defaultChecksumDir).MigrateTo(dst) // best-effort; a failed migration just leaves dst empty
+}
@@ -0,0 +1,323 @@
+// Daemon mode: a long-lived process that keeps an in-memory (path -> hash)
+// cache so repeat verifies on unchanged files skip re-hashing the file from
+// disk. The store/verify CLI entrypoints are clients of this daemon when one
+// is running, and fall back to the in-process path (see main.go) otherwise.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/hash"
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/hook"
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+	"github.com/fsnotify/fsnotify"
+)
+
+// socketPath returns the Unix domain socket the daemon listens on.
+func socketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "claude-fcg.sock")
+	}
+	return filepath.Join(defaultChecksumDir, "claude-fcg.sock")
+}
+
+// rpcRequest is a single length-prefixed JSON message sent to the daemon.
+// Op is one of "ping", "store", "verify", "query".
+type rpcRequest struct {
+	Op      string `json:"op"`
+	Payload []byte `json:"payload,omitempty"` // raw hook.Payload JSON for store/verify
+	Path    string `json:"path,omitempty"`    // for query
+}
+
+type rpcResponse struct {
+	OK                  bool   `json:"ok"`
+	Blocked             bool   `json:"blocked,omitempty"`
+	Reason              string `json:"reason,omitempty"`
+	Diff                string `json:"diff,omitempty"`
+	OverlapsPendingEdit bool   `json:"overlaps_pending_edit,omitempty"`
+	Status              string `json:"status,omitempty"`
+	Hash                string `json:"hash,omitempty"`
+	Error               string `json:"error,omitempty"`
+}
+
+// cacheEntry mirrors the (path -> (mtime, size, hash)) cache described in the
+// daemon design: once populated, verify/store can skip re-hashing a file
+// whose mtime and size haven't changed since it was last observed.
+type cacheEntry struct {
+	mtime time.Time
+	size  int64
+	hash  string
+}
+
+// fileCache is the daemon's in-memory hash cache, kept in sync with the
+// filesystem via fsnotify so external edits invalidate stale entries.
+type fileCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	watcher *fsnotify.Watcher
+	watched map[string]bool
+}
+
+func newFileCache() (*fileCache, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	fc := &fileCache{
+		entries: make(map[string]cacheEntry),
+		watcher: w,
+		watched: make(map[string]bool),
+	}
+	go fc.watchLoop()
+	return fc, nil
+}
+
+func (fc *fileCache) watchLoop() {
+	for {
+		select {
+		case ev, ok := <-fc.watcher.Events:
+			if !ok {
+				return
+			}
+			if ev.Op&(fsnotify.Write|fsnotify.Remove|fsnotify.Rename) != 0 {
+				fc.mu.Lock()
+				delete(fc.entries, ev.Name)
+				fc.mu.Unlock()
+			}
+		case err, ok := <-fc.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Println("fcg daemon: watcher error:", err)
+		}
+	}
+}
+
+// hash returns the sha256 of filePath, using the cached value when the
+// file's mtime and size haven't changed since the entry was recorded.
+func (fc *fileCache) hash(filePath string) (string, error) {
+	st, err := os.Stat(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	fc.mu.Lock()
+	entry, ok := fc.entries[filePath]
+	fc.mu.Unlock()
+	if ok && entry.mtime.Equal(st.ModTime()) && entry.size == st.Size() {
+		return entry.hash, nil
+	}
+
+	sum, err := hash.Checksum(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	fc.mu.Lock()
+	fc.entries[filePath] = cacheEntry{mtime: st.ModTime(), size: st.Size(), hash: sum}
+	alreadyWatched := fc.watched[filePath]
+	fc.mu.Unlock()
+
+	if !alreadyWatched {
+		if err := fc.watcher.Add(filePath); err == nil {
+			fc.mu.Lock()
+			fc.watched[filePath] = true
+			fc.mu.Unlock()
+		}
+	}
+	return sum, nil
+}
+
+// runDaemon starts listening on the Unix socket and serves Store/Verify/Query
+// RPCs against s until the process is killed.
+func runDaemon(s store.Store) error {
+	sp := socketPath()
+	os.Remove(sp) // stale socket from a previous, now-dead daemon
+
+	l, err := net.Listen("unix", sp)
+	if err != nil {
+		return fmt.Errorf("listen %s: %w", sp, err)
+	}
+	defer l.Close()
+
+	cache, err := newFileCache()
+	if err != nil {
+		return fmt.Errorf("create file cache: %w", err)
+	}
+	defer cache.watcher.Close()
+
+	log.Println("fcg daemon: listening on", sp)
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			log.Println("fcg daemon: accept error:", err)
+			continue
+		}
+		go handleConn(conn, s, cache)
+	}
+}
+
+func handleConn(conn net.Conn, s store.Store, cache *fileCache) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return
+	}
+
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		writeResponse(conn, rpcResponse{Error: err.Error()})
+		return
+	}
+
+	switch req.Op {
+	case "ping":
+		writeResponse(conn, rpcResponse{OK: true})
+
+	case "store":
+		var payload hook.Payload
+		if err := json.Unmarshal(req.Payload, &payload); err != nil {
+			writeResponse(conn, rpcResponse{Error: err.Error()})
+			return
+		}
+		filePath := hook.ResolveFilePath(payload)
+		current, err := cache.hash(filePath)
+		if err != nil {
+			writeResponse(conn, rpcResponse{Error: err.Error()})
+			return
+		}
+		status, current, stored := hook.CompareStatusWithHash(s, filePath, current)
+		if err := hook.Store(s, filePath); err != nil {
+			writeResponse(conn, rpcResponse{Error: err.Error()})
+			return
+		}
+		s.Log(filePath, hook.LogEntry("store", payload.ToolName, status, current, stored))
+		cache.mu.Lock()
+		delete(cache.entries, filePath) // force a re-stat/re-hash next verify
+		cache.mu.Unlock()
+		writeResponse(conn, rpcResponse{OK: true, Status: status})
+
+	case "verify":
+		var payload hook.Payload
+		if err := json.Unmarshal(req.Payload, &payload); err != nil {
+			writeResponse(conn, rpcResponse{Error: err.Error()})
+			return
+		}
+		filePath := hook.ResolveFilePath(payload)
+		current, err := cache.hash(filePath)
+		if err != nil {
+			writeResponse(conn, rpcResponse{Error: err.Error()})
+			return
+		}
+		status, current, stored := hook.CompareStatusWithHash(s, filePath, current)
+		s.Log(filePath, hook.LogEntry("verify", payload.ToolName, status, current, stored))
+		if status == "match" {
+			// Unchanged since last store/verify: the cached hash already
+			// proves there's nothing to block on, so skip Verify's full
+			// re-read and diff.
+			writeResponse(conn, rpcResponse{OK: true, Status: status})
+			return
+		}
+		blocked, reason, diff, overlaps := hook.Verify(s, filePath, payload.ToolInput)
+		writeResponse(conn, rpcResponse{OK: true, Blocked: blocked, Reason: reason, Diff: diff, OverlapsPendingEdit: overlaps, Status: status})
+
+	case "query":
+		h, err := cache.hash(req.Path)
+		if err != nil {
+			writeResponse(conn, rpcResponse{Error: err.Error()})
+			return
+		}
+		writeResponse(conn, rpcResponse{OK: true, Hash: h})
+
+	default:
+		writeResponse(conn, rpcResponse{Error: "unknown op: " + req.Op})
+	}
+}
+
+func writeResponse(conn net.Conn, resp rpcResponse) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	b = append(b, '\n')
+	conn.Write(b)
+}
+
+// probeDaemon reports whether a daemon is listening and responsive, by
+// opening a connection, pinging it, and closing it again. It does not reuse
+// the connection: handleConn serves exactly one request per connection, so a
+// probe connection can't also carry the real store/verify request.
+func probeDaemon() bool {
+	sp := socketPath()
+	if _, err := os.Stat(sp); err != nil {
+		return false
+	}
+	conn, err := net.DialTimeout("unix", sp, 200*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(append(mustJSON(rpcRequest{Op: "ping"}), '\n')); err != nil {
+		return false
+	}
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadBytes('\n')
+	conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		return false
+	}
+	var resp rpcResponse
+	if err := json.Unmarshal(line, &resp); err != nil || !resp.OK {
+		return false
+	}
+	return true
+}
+
+// forwardToDaemon sends a store/verify request to a running daemon over a
+// fresh connection. The bool return reports whether a daemon was reachable
+// at all; callers fall back to the in-process path when it's false.
+func forwardToDaemon(op string, payloadJSON []byte) (rpcResponse, bool) {
+	if !probeDaemon() {
+		return rpcResponse{}, false
+	}
+
+	sp := socketPath()
+	conn, err := net.DialTimeout("unix", sp, 200*time.Millisecond)
+	if err != nil {
+		return rpcResponse{}, false
+	}
+	defer conn.Close()
+
+	req := rpcRequest{Op: op, Payload: payloadJSON}
+	if _, err := conn.Write(append(mustJSON(req), '\n')); err != nil {
+		return rpcResponse{}, false
+	}
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return rpcResponse{}, false
+	}
+	var resp rpcResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return rpcResponse{}, false
+	}
+	return resp, true
+}
+
+func mustJSON(v interface{}) []byte {
+	b, _ := json.Marshal(v)
+	return b
+}
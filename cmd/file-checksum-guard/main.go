@@ -0,0 +1,117 @@
+// Claude Code hook that blocks edits to files modified externally since Claude last read them.
+// Invoked as: echo '<json>' | file-checksum-guard store   (after a read)
+//
+//	echo '<json>' | file-checksum-guard verify  (before an edit)
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/hook"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: file-checksum-guard <verify|store|reconcile|daemon|serve>")
+		os.Exit(1)
+	}
+	action := os.Args[1]
+
+	cfg := loadConfig()
+	s, err := openStore(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to open store:", err)
+		os.Exit(1)
+	}
+	migrateIfNeeded(cfg, s)
+
+	if action == "daemon" {
+		if err := runDaemon(s); err != nil {
+			fmt.Fprintln(os.Stderr, "daemon error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if action == "serve" {
+		fs := flag.NewFlagSet("serve", flag.ExitOnError)
+		addr := fs.String("addr", "127.0.0.1:7333", "address to listen on")
+		fs.Parse(os.Args[2:])
+		if err := runServe(s, *addr); err != nil {
+			fmt.Fprintln(os.Stderr, "serve error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to read stdin:", err)
+		os.Exit(1)
+	}
+
+	// `&payload` passes a pointer so Unmarshal can mutate the struct in place.
+	var payload hook.Payload
+	if err := json.Unmarshal(input, &payload); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to parse JSON:", err)
+		os.Exit(1)
+	}
+
+	filePath := hook.ResolveFilePath(payload)
+	if filePath == "" {
+		os.Exit(0)
+	}
+
+	switch action {
+	case "store":
+		if resp, ok := forwardToDaemon("store", input); ok {
+			if resp.Error != "" {
+				fmt.Fprintln(os.Stderr, "store error:", resp.Error)
+				os.Exit(1)
+			}
+			return
+		}
+
+		status, current, stored := hook.CompareStatus(s, filePath)
+		if err := hook.Store(s, filePath); err != nil {
+			fmt.Fprintln(os.Stderr, "store error:", err)
+			os.Exit(1)
+		}
+		s.Log(filePath, hook.LogEntry("store", payload.ToolName, status, current, stored))
+
+	case "verify":
+		if resp, ok := forwardToDaemon("verify", input); ok {
+			if resp.Error != "" {
+				fmt.Fprintln(os.Stderr, "verify error:", resp.Error)
+				os.Exit(1)
+			}
+			if resp.Blocked {
+				out, _ := json.Marshal(hook.BlockResponse{Reason: resp.Reason, Diff: resp.Diff, OverlapsPendingEdit: resp.OverlapsPendingEdit})
+				fmt.Println(string(out))
+				os.Exit(2)
+			}
+			return
+		}
+
+		status, current, stored := hook.CompareStatus(s, filePath)
+		s.Log(filePath, hook.LogEntry("verify", payload.ToolName, status, current, stored))
+		if blocked, reason, diff, overlaps := hook.Verify(s, filePath, payload.ToolInput); blocked {
+			// `_` discards the error from Marshal — safe here since
+			// BlockResponse is a trivial struct that can't fail to serialize.
+			resp, _ := json.Marshal(hook.BlockResponse{Reason: reason, Diff: diff, OverlapsPendingEdit: overlaps})
+			fmt.Println(string(resp))
+			os.Exit(2) // exit code 2 = "block this tool call" in Claude Code hooks
+		}
+
+	case "reconcile":
+		runReconcile(s, payload, filePath)
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown action: %s\n", action)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/hook"
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+)
+
+// runReconcile implements the `reconcile` CLI action: merge the pending
+// edit (payload.ToolInput.OldString/NewString) against the external change
+// to filePath, and print the result as JSON.
+func runReconcile(s store.Store, payload hook.Payload, filePath string) {
+	result, err := hook.Reconcile(s, filePath, payload.ToolInput)
+	if err != nil {
+		result = hook.ReconcileResult{Approved: false, Reason: err.Error()}
+	}
+	printReconcile(result)
+}
+
+func printReconcile(result hook.ReconcileResult) {
+	b, err := json.Marshal(result)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "reconcile error:", err)
+		os.Exit(1)
+	}
+	io.WriteString(os.Stdout, string(b)+"\n")
+	if !result.Approved {
+		os.Exit(2)
+	}
+}
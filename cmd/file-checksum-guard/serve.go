@@ -0,0 +1,325 @@
+// Local web UI and JSON API over the access log recorded by store/verify,
+// for inspecting why Claude is blocking an edit without grepping flat log
+// files by hand.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/hash"
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+)
+
+// runServe implements the `serve` CLI action: it starts an HTTP server over
+// s's access log and blocks until the process is killed.
+func runServe(s store.Store, addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", indexHandler(s))
+	mux.HandleFunc("/api/events", apiEventsHandler(s))
+	mux.HandleFunc("/api/files", apiFilesHandler(s))
+	mux.HandleFunc("/api/file/", apiFileHandler(s))
+
+	if !isLoopback(addr) && os.Getenv("FCG_HTTP_USER") == "" && os.Getenv("FCG_HTTP_PASS") == "" && os.Getenv("FCG_HTTP_SECRET") == "" {
+		fmt.Fprintln(os.Stderr, "fcg serve: WARNING: listening on", addr, "with no FCG_HTTP_USER/PASS or FCG_HTTP_SECRET set — the access log (file paths, hashes, edit history) is reachable by anyone who can reach this address")
+	}
+
+	fmt.Fprintln(os.Stderr, "fcg serve: listening on", addr)
+	return http.ListenAndServe(addr, requireAuth(mux))
+}
+
+// isLoopback reports whether addr's host is a loopback address (or empty,
+// i.e. "all interfaces" is NOT loopback and returns false).
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if host == "localhost" {
+		return true
+	}
+	return net.ParseIP(host).IsLoopback()
+}
+
+// requireAuth gates every request behind HTTP Basic auth
+// (FCG_HTTP_USER/FCG_HTTP_PASS) or, as a lighter-weight alternative for a
+// dev container, a shared secret in the X-FCG-Secret header
+// (FCG_HTTP_SECRET). With neither set, requests are allowed unauthenticated
+// — fine for binding to 127.0.0.1, but callers exposing --addr beyond
+// localhost should set one.
+func requireAuth(next http.Handler) http.Handler {
+	user := os.Getenv("FCG_HTTP_USER")
+	pass := os.Getenv("FCG_HTTP_PASS")
+	secret := os.Getenv("FCG_HTTP_SECRET")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if secret != "" {
+			if r.Header.Get("X-FCG-Secret") != secret {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		if user != "" || pass != "" {
+			gotUser, gotPass, ok := r.BasicAuth()
+			if !ok || gotUser != user || gotPass != pass {
+				w.Header().Set("WWW-Authenticate", `Basic realm="file-checksum-guard"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// badFilterError marks a filteredEvents failure as the caller's fault (a
+// malformed query parameter) rather than a store-level error, so handlers
+// can report it as 400 instead of 500.
+type badFilterError struct{ error }
+
+// filteredEvents returns s.Events(), most recent first, narrowed by the
+// request's tool/status/since/until query parameters (any left blank match
+// everything). An unparseable since/until returns a badFilterError rather
+// than silently matching everything.
+func filteredEvents(s store.Store, r *http.Request) ([]store.Event, error) {
+	tool := r.URL.Query().Get("tool")
+	status := r.URL.Query().Get("status")
+
+	var since, until time.Time
+	var err error
+	if v := r.URL.Query().Get("since"); v != "" {
+		if since, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, badFilterError{fmt.Errorf("invalid since (want RFC3339): %w", err)}
+		}
+	}
+	if v := r.URL.Query().Get("until"); v != "" {
+		if until, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, badFilterError{fmt.Errorf("invalid until (want RFC3339): %w", err)}
+		}
+	}
+
+	events, err := s.Events()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := events[:0]
+	for _, e := range events {
+		if tool != "" && e.Tool != tool {
+			continue
+		}
+		if status != "" && e.Status != status {
+			continue
+		}
+		if !since.IsZero() && e.Time.Before(since) {
+			continue
+		}
+		if !until.IsZero() && e.Time.After(until) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Time.After(filtered[j].Time) })
+	return filtered, nil
+}
+
+func apiEventsHandler(s store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		events, err := filteredEvents(s, r)
+		if err != nil {
+			writeEventsError(w, err)
+			return
+		}
+		writeJSON(w, events)
+	}
+}
+
+// fileSummary is one row of /api/files: a tracked path plus its most recent
+// access-log status.
+type fileSummary struct {
+	Path       string    `json:"path"`
+	Hash       string    `json:"hash"`
+	LastStatus string    `json:"last_status,omitempty"`
+	LastSeen   time.Time `json:"last_seen,omitempty"`
+}
+
+func fileSummaries(s store.Store) ([]fileSummary, error) {
+	paths, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.Events()
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]store.Event, len(events))
+	for _, e := range events {
+		if cur, ok := latest[e.Path]; !ok || e.Time.After(cur.Time) {
+			latest[e.Path] = e
+		}
+	}
+
+	summaries := make([]fileSummary, 0, len(paths))
+	for _, p := range paths {
+		sum := fileSummary{Path: p, Hash: hash.Sum([]byte(p))}
+		if e, ok := latest[p]; ok {
+			sum.LastStatus = e.Status
+			sum.LastSeen = e.Time
+		}
+		summaries = append(summaries, sum)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Path < summaries[j].Path })
+	return summaries, nil
+}
+
+func apiFilesHandler(s store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		summaries, err := fileSummaries(s)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, summaries)
+	}
+}
+
+// apiFileHandler serves /api/file/<hash>, where hash is hash.Sum of the
+// tracked path (the same addressing fsstore uses internally), returning
+// that file's timeline oldest-first.
+func apiFileHandler(s store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h := strings.TrimPrefix(r.URL.Path, "/api/file/")
+		if h == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		events, err := s.Events()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var timeline []store.Event
+		for _, e := range events {
+			if hash.Sum([]byte(e.Path)) == h {
+				timeline = append(timeline, e)
+			}
+		}
+		sort.Slice(timeline, func(i, j int) bool { return timeline[i].Time.Before(timeline[j].Time) })
+		writeJSON(w, timeline)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeEventsError reports a filteredEvents failure as 400 if it was a
+// malformed query parameter, 500 otherwise.
+func writeEventsError(w http.ResponseWriter, err error) {
+	var bad badFilterError
+	if errors.As(err, &bad) {
+		http.Error(w, bad.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// eventView adds the file-hash link target to a store.Event for the index
+// template.
+type eventView struct {
+	store.Event
+	Hash string
+}
+
+// indexTemplate renders the event table and its filter form. It's plain
+// server-rendered HTML rather than an actual htmx include — pulling in a
+// CDN script would break in an offline dev container, which is exactly
+// where this is meant to run.
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>file-checksum-guard</title>
+<style>
+body { font-family: monospace; margin: 2rem; background: #111; color: #ddd; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #333; padding: .25rem .5rem; text-align: left; }
+.status-mismatch { color: #f66; }
+.status-match { color: #6c6; }
+.status-new { color: #69c; }
+.status-error { color: #fa6; }
+form { margin-bottom: 1rem; }
+a { color: #6cf; }
+</style>
+</head>
+<body>
+<h1>file-checksum-guard</h1>
+<form method="get">
+  <input type="text" name="tool" placeholder="tool" value="{{.Tool}}">
+  <select name="status">
+    <option value="">any status</option>
+    <option value="match" {{if eq .Status "match"}}selected{{end}}>match</option>
+    <option value="mismatch" {{if eq .Status "mismatch"}}selected{{end}}>mismatch</option>
+    <option value="new" {{if eq .Status "new"}}selected{{end}}>new</option>
+    <option value="error" {{if eq .Status "error"}}selected{{end}}>error</option>
+  </select>
+  <input type="text" name="since" placeholder="since (RFC3339)" value="{{.Since}}">
+  <input type="text" name="until" placeholder="until (RFC3339)" value="{{.Until}}">
+  <button type="submit">filter</button>
+</form>
+<table>
+<tr><th>time</th><th>action</th><th>tool</th><th>status</th><th>file</th></tr>
+{{range .Events}}
+<tr class="status-{{.Status}}">
+  <td>{{.Time.Format "2006-01-02T15:04:05Z07:00"}}</td>
+  <td>{{.Action}}</td>
+  <td>{{.Tool}}</td>
+  <td>{{.Status}}</td>
+  <td><a href="/api/file/{{.Hash}}">{{.Path}}</a></td>
+</tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
+
+func indexHandler(s store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		events, err := filteredEvents(s, r)
+		if err != nil {
+			writeEventsError(w, err)
+			return
+		}
+
+		views := make([]eventView, 0, len(events))
+		for _, e := range events {
+			views = append(views, eventView{Event: e, Hash: hash.Sum([]byte(e.Path))})
+		}
+
+		data := struct {
+			Events                     []eventView
+			Tool, Status, Since, Until string
+		}{
+			Events: views,
+			Tool:   r.URL.Query().Get("tool"),
+			Status: r.URL.Query().Get("status"),
+			Since:  r.URL.Query().Get("since"),
+			Until:  r.URL.Query().Get("until"),
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		indexTemplate.Execute(w, data)
+	}
+}
@@ -0,0 +1,85 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// WindowSize is the width of the rolling-hash window used to find cut points.
+	WindowSize = 64
+	// TargetSize is the chunk size the rolling hash aims for on average.
+	TargetSize = 16 * 1024
+	// MinSize is the smallest chunk Split will emit (except possibly the last).
+	MinSize = 4 * 1024
+	// MaxSize forces a cut even if the rolling hash hasn't found one, bounding
+	// worst-case chunk size (e.g. for highly repetitive input).
+	MaxSize = 64 * 1024
+)
+
+// cutMask is chosen so that, for pseudo-random content, a rolling-hash value
+// has a 1-in-TargetSize chance of matching it at any given position.
+const cutMask = uint64(TargetSize - 1)
+
+// rollingPrime and primePowWindow implement a Rabin-style rolling hash over
+// a fixed-width window, evaluated at every byte position across the whole
+// input (not reset at chunk boundaries). That continuity is what makes cut
+// points depend only on local content: the hash at position i is a function
+// of data[i-WindowSize+1 .. i] alone.
+const rollingPrime = uint64(1000000007)
+
+var primePowWindow = func() uint64 {
+	p := uint64(1)
+	for i := 0; i < WindowSize; i++ {
+		p *= rollingPrime
+	}
+	return p
+}()
+
+// Chunk is one content-defined slice of a file: its byte offset, length, and
+// the SHA-256 of its contents.
+type Chunk struct {
+	Offset int64  `json:"offset"`
+	Len    int    `json:"len"`
+	Hash   string `json:"hash"`
+}
+
+// Split divides data into content-defined chunks. Callers should only use
+// this for inputs at or above a size threshold (see the chunkThreshold
+// constant in the caller) — below that, a single whole-file hash is both
+// cheaper and sufficient.
+func Split(data []byte) []Chunk {
+	n := len(data)
+	if n == 0 {
+		return nil
+	}
+
+	var chunks []Chunk
+	start := 0
+	var h uint64
+	for i := 0; i < n; i++ {
+		h = h*rollingPrime + uint64(data[i])
+		if i >= WindowSize {
+			h -= uint64(data[i-WindowSize]) * primePowWindow
+		}
+
+		size := i - start + 1
+		if size >= MinSize && (size >= MaxSize || h&cutMask == 0) {
+			chunks = append(chunks, newChunk(data, start, i))
+			start = i + 1
+		}
+	}
+	if start < n {
+		chunks = append(chunks, newChunk(data, start, n-1))
+	}
+	return chunks
+}
+
+func newChunk(data []byte, start, end int) Chunk {
+	sum := sha256.Sum256(data[start : end+1])
+	return Chunk{
+		Offset: int64(start),
+		Len:    end - start + 1,
+		Hash:   hex.EncodeToString(sum[:]),
+	}
+}
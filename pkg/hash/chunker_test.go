@@ -0,0 +1,111 @@
+package hash
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func randomData(n int, seed int64) []byte {
+	r := rand.New(rand.NewSource(seed))
+	data := make([]byte, n)
+	r.Read(data)
+	return data
+}
+
+func TestSplitRespectsSizeBounds(t *testing.T) {
+	data := randomData(500*1024, 1)
+	chunks := Split(data)
+	if len(chunks) == 0 {
+		t.Fatal("expected at least one chunk")
+	}
+	for i, c := range chunks {
+		last := i == len(chunks)-1
+		if c.Len < MinSize && !last {
+			t.Errorf("chunk %d: len %d below MinSize %d", i, c.Len, MinSize)
+		}
+		if c.Len > MaxSize {
+			t.Errorf("chunk %d: len %d above MaxSize %d", i, c.Len, MaxSize)
+		}
+	}
+}
+
+func TestSplitIsContiguousAndCoversInput(t *testing.T) {
+	data := randomData(200*1024, 2)
+	chunks := Split(data)
+	var offset int64
+	for i, c := range chunks {
+		if c.Offset != offset {
+			t.Fatalf("chunk %d: offset %d, want %d", i, c.Offset, offset)
+		}
+		offset += int64(c.Len)
+	}
+	if offset != int64(len(data)) {
+		t.Fatalf("chunks cover %d bytes, want %d", offset, len(data))
+	}
+}
+
+// TestInsertionDoesNotReshapeDownstreamChunks is the core content-defined
+// chunking invariant: inserting bytes early in the input must not change the
+// hashes of chunks that lie entirely after the insertion's local effect
+// fades out (i.e. once the rolling-hash window has slid past it).
+func TestInsertionDoesNotReshapeDownstreamChunks(t *testing.T) {
+	original := randomData(300*1024, 3)
+	before := Split(original)
+	if len(before) < 3 {
+		t.Fatalf("need at least 3 chunks in the fixture, got %d", len(before))
+	}
+
+	inserted := make([]byte, 0, len(original)+1)
+	inserted = append(inserted, original[:1000]...)
+	inserted = append(inserted, 'X') // single extra byte, well before later chunks
+	inserted = append(inserted, original[1000:]...)
+	after := Split(inserted)
+
+	beforeHashes := make(map[string]bool, len(before))
+	for _, c := range before {
+		beforeHashes[c.Hash] = true
+	}
+
+	// Every chunk after the one containing the insertion point should have an
+	// identical hash to some chunk in the original split — same content, just
+	// shifted by one byte of offset.
+	matched := 0
+	for _, c := range after {
+		if c.Offset < 1000 {
+			continue
+		}
+		if beforeHashes[c.Hash] {
+			matched++
+		}
+	}
+	if matched == 0 {
+		t.Fatal("expected at least some downstream chunks to be unaffected by an early insertion")
+	}
+	if matched < len(after)-2 {
+		t.Errorf("insertion reshaped too many downstream chunks: only %d/%d matched", matched, len(after))
+	}
+}
+
+func TestSplitEmpty(t *testing.T) {
+	if chunks := Split(nil); chunks != nil {
+		t.Errorf("Split(nil) = %v, want nil", chunks)
+	}
+	if chunks := Split([]byte{}); chunks != nil {
+		t.Errorf("Split(empty) = %v, want nil", chunks)
+	}
+}
+
+func TestSplitDeterministic(t *testing.T) {
+	data := randomData(100*1024, 4)
+	a := Split(data)
+	b := Split(bytes.Clone(data))
+	if len(a) != len(b) {
+		t.Fatalf("non-deterministic chunk count: %d vs %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("chunk %d differs between runs: %+v vs %+v", i, a[i], b[i])
+		}
+	}
+}
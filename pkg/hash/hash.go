@@ -0,0 +1,33 @@
+// Package hash is the hasher used to fingerprint tracked files: a whole-file
+// SHA-256 (Checksum/Sum) plus, for larger files, a content-defined chunker
+// (Split) so a stale report can say which byte ranges actually changed
+// instead of just "the whole file is stale".
+package hash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+)
+
+// Checksum returns the hex-encoded SHA-256 of the file at path.
+func Checksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Sum returns the hex-encoded SHA-256 of data.
+func Sum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
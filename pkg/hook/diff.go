@@ -0,0 +1,229 @@
+// Line-based diffing shared by verify's unified-diff report and reconcile's
+// three-way merge. Deliberately simple (LCS dynamic programming, O(n*m)) —
+// fine for the source-file-sized inputs this guard deals with.
+package hook
+
+import "fmt"
+
+// opTag identifies one diff opcode, mirroring Python's difflib.get_opcodes.
+type opTag byte
+
+const (
+	opEqual opTag = iota
+	opReplace
+	opDelete
+	opInsert
+)
+
+// diffOp is a maximal run where a and b agree (opEqual) or disagree
+// (opReplace/opDelete/opInsert), expressed as half-open line ranges.
+type diffOp struct {
+	tag          opTag
+	aStart, aEnd int
+	bStart, bEnd int
+}
+
+// maxDiffLines bounds the O(n*m) LCS table; beyond this we still produce a
+// single "everything changed" opcode rather than refusing to run.
+const maxDiffLines = 4000
+
+// diffLines returns the opcodes transforming a into b.
+func diffLines(a, b []string) []diffOp {
+	if len(a) > maxDiffLines || len(b) > maxDiffLines {
+		return []diffOp{{tag: opReplace, aStart: 0, aEnd: len(a), bStart: 0, bEnd: len(b)}}
+	}
+
+	n, m := len(a), len(b)
+	// lcs[i][j] = length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	// Walk the LCS table to emit one opcode per line, then coalesce
+	// consecutive opcodes of the same kind (and adjacent delete+insert runs
+	// into a single replace) into maximal runs.
+	var raw []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			raw = append(raw, diffOp{tag: opEqual, aStart: i, aEnd: i + 1, bStart: j, bEnd: j + 1})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			raw = append(raw, diffOp{tag: opDelete, aStart: i, aEnd: i + 1, bStart: j, bEnd: j})
+			i++
+		default:
+			raw = append(raw, diffOp{tag: opInsert, aStart: i, aEnd: i, bStart: j, bEnd: j + 1})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		raw = append(raw, diffOp{tag: opDelete, aStart: i, aEnd: i + 1, bStart: j, bEnd: j})
+	}
+	for ; j < m; j++ {
+		raw = append(raw, diffOp{tag: opInsert, aStart: i, aEnd: i, bStart: j, bEnd: j + 1})
+	}
+	return coalesceOps(raw)
+}
+
+// coalesceOps merges consecutive same-tag opcodes into maximal runs, and an
+// adjacent delete+insert run into a single replace — both more compact and
+// what three-way merge conflict detection expects a "changed region" to
+// look like.
+func coalesceOps(ops []diffOp) []diffOp {
+	var out []diffOp
+	for _, op := range ops {
+		if len(out) > 0 {
+			last := &out[len(out)-1]
+			switch {
+			case last.tag == op.tag:
+				last.aEnd, last.bEnd = op.aEnd, op.bEnd
+				continue
+			case last.tag == opDelete && op.tag == opInsert && last.aEnd == op.aStart:
+				out[len(out)-1] = diffOp{tag: opReplace, aStart: last.aStart, aEnd: last.aEnd, bStart: op.bStart, bEnd: op.bEnd}
+				continue
+			case last.tag == opInsert && op.tag == opDelete && last.bEnd == op.bStart:
+				out[len(out)-1] = diffOp{tag: opReplace, aStart: op.aStart, aEnd: op.aEnd, bStart: last.bStart, bEnd: last.bEnd}
+				continue
+			case (last.tag == opReplace || last.tag == opDelete) && op.tag == opDelete:
+				last.aEnd = op.aEnd
+				continue
+			case (last.tag == opReplace || last.tag == opInsert) && op.tag == opInsert && last.tag == opReplace:
+				last.bEnd = op.bEnd
+				continue
+			}
+		}
+		out = append(out, op)
+	}
+	return out
+}
+
+// unifiedDiff renders a unified diff (like `diff -u`) between aLines and
+// bLines, labeled with the given "from"/"to" names.
+func unifiedDiff(fromLabel, toLabel string, aLines, bLines []string, context int) string {
+	ops := diffLines(aLines, bLines)
+
+	var changed []diffOp
+	for _, op := range ops {
+		if op.tag != opEqual {
+			changed = append(changed, op)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	out := fmt.Sprintf("--- %s\n+++ %s\n", fromLabel, toLabel)
+	for _, group := range groupHunks(ops, context) {
+		out += renderHunk(aLines, bLines, group)
+	}
+	return out
+}
+
+// groupHunks clusters opcodes into hunks, merging changes that are within
+// 2*context lines of each other (standard unified-diff behavior) and keeps
+// up to `context` lines of the surrounding/interior opEqual runs as
+// rendered context — trimmed to the nearest `context` lines when a run is
+// longer than that, dropped entirely when context is 0.
+func groupHunks(ops []diffOp, context int) [][]diffOp {
+	var hunks [][]diffOp
+	var current []diffOp
+	for idx, op := range ops {
+		if op.tag != opEqual {
+			current = append(current, op)
+			continue
+		}
+
+		length := op.aEnd - op.aStart
+		switch {
+		case len(current) == 0:
+			// Leading context before the first change in a hunk.
+			if idx == len(ops)-1 {
+				continue // trailing equal run with no change after it
+			}
+			aStart, bStart := op.aStart, op.bStart
+			if length > context {
+				aStart, bStart = op.aEnd-context, op.bEnd-context
+			}
+			current = append(current, diffOp{tag: opEqual, aStart: aStart, aEnd: op.aEnd, bStart: bStart, bEnd: op.bEnd})
+
+		case idx == len(ops)-1:
+			// Trailing context after the last change.
+			aEnd, bEnd := op.aEnd, op.bEnd
+			if length > context {
+				aEnd, bEnd = op.aStart+context, op.bStart+context
+			}
+			current = append(current, diffOp{tag: opEqual, aStart: op.aStart, aEnd: aEnd, bStart: op.bStart, bEnd: bEnd})
+			hunks = append(hunks, current)
+			current = nil
+
+		case length > 2*context:
+			// Gap too wide to bridge: close the current hunk with trailing
+			// context, start the next one with leading context.
+			current = append(current, diffOp{tag: opEqual, aStart: op.aStart, aEnd: op.aStart + context, bStart: op.bStart, bEnd: op.bStart + context})
+			hunks = append(hunks, current)
+			current = []diffOp{{tag: opEqual, aStart: op.aEnd - context, aEnd: op.aEnd, bStart: op.bEnd - context, bEnd: op.bEnd}}
+
+		default:
+			// Small enough gap: keep it whole as interior context, merging
+			// the two hunks.
+			current = append(current, op)
+		}
+	}
+	if len(current) > 0 {
+		hunks = append(hunks, current)
+	}
+	return hunks
+}
+
+// renderHunk renders one hunk's opcodes as unified-diff lines, with the
+// header's line counts derived from the context/added/removed lines actually
+// emitted in the body.
+func renderHunk(aLines, bLines []string, ops []diffOp) string {
+	aStart, bStart := ops[0].aStart, ops[0].bStart
+
+	var body string
+	var aCount, bCount int
+	for _, op := range ops {
+		switch op.tag {
+		case opEqual:
+			for _, l := range aLines[op.aStart:op.aEnd] {
+				body += " " + l + "\n"
+			}
+			aCount += op.aEnd - op.aStart
+			bCount += op.bEnd - op.bStart
+		case opDelete, opReplace:
+			for _, l := range aLines[op.aStart:op.aEnd] {
+				body += "-" + l + "\n"
+			}
+			aCount += op.aEnd - op.aStart
+			if op.tag == opReplace {
+				for _, l := range bLines[op.bStart:op.bEnd] {
+					body += "+" + l + "\n"
+				}
+				bCount += op.bEnd - op.bStart
+			}
+		case opInsert:
+			for _, l := range bLines[op.bStart:op.bEnd] {
+				body += "+" + l + "\n"
+			}
+			bCount += op.bEnd - op.bStart
+		}
+	}
+
+	header := fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", aStart+1, aCount, bStart+1, bCount)
+	return header + body
+}
@@ -0,0 +1,46 @@
+package hook
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiffNoChange(t *testing.T) {
+	lines := []string{"a", "b", "c"}
+	if d := unifiedDiff("x", "y", lines, lines, 3); d != "" {
+		t.Errorf("expected no diff, got %q", d)
+	}
+}
+
+func TestUnifiedDiffSingleLineChange(t *testing.T) {
+	a := []string{"one", "two", "three"}
+	b := []string{"one", "TWO", "three"}
+	d := unifiedDiff("before", "after", a, b, 3)
+	if d == "" {
+		t.Fatal("expected a non-empty diff")
+	}
+	for _, want := range []string{"-two", "+TWO", "--- before", "+++ after"} {
+		if !strings.Contains(d, want) {
+			t.Errorf("diff missing %q:\n%s", want, d)
+		}
+	}
+}
+
+// TestUnifiedDiffEmitsContextLines covers two changes far enough apart to
+// land in separate hunks: each hunk must carry its context lines in the
+// body (not just in the @@ header counts) and each header's counts must
+// match the lines actually rendered under it.
+func TestUnifiedDiffEmitsContextLines(t *testing.T) {
+	a := []string{"l1", "l2", "l3", "l4", "l5", "l6", "l7", "l8"}
+	b := []string{"X1", "l2", "l3", "l4", "l5", "l6", "l7", "X8"}
+	d := unifiedDiff("a", "b", a, b, 2)
+
+	for _, want := range []string{
+		"@@ -1,3 +1,3 @@\n-l1\n+X1\n l2\n l3\n",
+		"@@ -6,3 +6,3 @@\n l6\n l7\n-l8\n+X8\n",
+	} {
+		if !strings.Contains(d, want) {
+			t.Errorf("diff missing hunk:\n%s\ngot:\n%s", want, d)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package hook implements the actual store/verify/reconcile logic behind
+// file-checksum-guard's Claude Code hook actions: parsing the hook payload,
+// comparing against a pkg/store.Store, and building the stale-file reports
+// (line ranges, unified diffs, three-way merges) the CLI and daemon return.
+package hook
+
+import "path/filepath"
+
+// ToolInput is the subset of a Claude Code tool call's input fields the
+// guard cares about. OldString/NewString are only present for Edit calls,
+// and are used by Reconcile as the "theirs" side of a three-way merge.
+type ToolInput struct {
+	FilePath     string `json:"file_path"`
+	RelativePath string `json:"relative_path"`
+	OldString    string `json:"old_string"`
+	NewString    string `json:"new_string"`
+}
+
+// Payload is a Claude Code PreToolUse/PostToolUse hook payload.
+type Payload struct {
+	ToolName  string    `json:"tool_name"`
+	ToolInput ToolInput `json:"tool_input"`
+	Cwd       string    `json:"cwd"`
+}
+
+// BlockResponse is printed to stdout when verify blocks a tool call.
+type BlockResponse struct {
+	Reason string `json:"reason"`
+	// Diff, when non-empty, is a unified diff between the snapshot Claude
+	// last saw and the file's current on-disk contents.
+	Diff string `json:"diff,omitempty"`
+	// OverlapsPendingEdit reports whether the externally changed region
+	// intersects the old_string Claude's pending edit is targeting.
+	OverlapsPendingEdit bool `json:"overlaps_pending_edit,omitempty"`
+}
+
+// ResolveFilePath applies the file_path/relative_path+cwd fallback shared by
+// every hook action.
+func ResolveFilePath(p Payload) string {
+	filePath := p.ToolInput.FilePath
+	if filePath == "" && p.ToolInput.RelativePath != "" {
+		filePath = filepath.Join(p.Cwd, p.ToolInput.RelativePath)
+	}
+	return filePath
+}
@@ -0,0 +1,131 @@
+// Chunk manifests let Verify report *which* regions of a stale file
+// changed, instead of only that the whole file is stale. A manifest lists
+// the content-defined chunks (see pkg/hash) computed the last time Store
+// ran, plus the whole-file hash. Files below chunkThreshold skip this
+// entirely and rely on the single-hash comparison alone.
+package hook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/hash"
+)
+
+// chunkThreshold is the file size below which chunking is skipped in favor
+// of today's single whole-file hash.
+const chunkThreshold = 4 * 1024
+
+// ChunkManifest is the persisted record of a file's chunk layout as of the
+// last Store call.
+type ChunkManifest struct {
+	WholeHash string       `json:"whole_hash"`
+	Chunks    []hash.Chunk `json:"chunks"`
+}
+
+// buildManifest returns the JSON-encoded manifest for data, or nil if data
+// is below chunkThreshold (chunking isn't worth it).
+func buildManifest(data []byte, wholeHash string) []byte {
+	if len(data) < chunkThreshold {
+		return nil
+	}
+	manifest := ChunkManifest{WholeHash: wholeHash, Chunks: hash.Split(data)}
+	b, err := json.Marshal(manifest)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// changedRanges diffs a stored manifest against the file's current content,
+// returning the byte ranges of chunks whose content is no longer present in
+// the manifest.
+func changedRanges(manifestJSON, data []byte) []byteRange {
+	var manifest ChunkManifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return nil
+	}
+
+	stored := make(map[string]bool, len(manifest.Chunks))
+	for _, c := range manifest.Chunks {
+		stored[c.Hash] = true
+	}
+
+	var ranges []byteRange
+	for _, c := range hash.Split(data) {
+		if stored[c.Hash] {
+			continue
+		}
+		ranges = append(ranges, byteRange{start: c.Offset, end: c.Offset + int64(c.Len) - 1})
+	}
+	return mergeAdjacent(ranges)
+}
+
+type byteRange struct{ start, end int64 }
+
+// mergeAdjacent coalesces back-to-back changed chunks into a single range so
+// the report reads as one contiguous region instead of several small ones.
+func mergeAdjacent(ranges []byteRange) []byteRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+
+	merged := []byteRange{ranges[0]}
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r.start <= last.end+1 {
+			if r.end > last.end {
+				last.end = r.end
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// lineRangesReason turns byte ranges into a 1-indexed line-range description
+// by scanning data once and recording which line each range's bytes fall on.
+func lineRangesReason(data []byte, ranges []byteRange) string {
+	if len(ranges) == 0 {
+		return ""
+	}
+
+	lineStarts := []int64{0}
+	for i, b := range data {
+		if b == '\n' {
+			lineStarts = append(lineStarts, int64(i+1))
+		}
+	}
+	lineOf := func(offset int64) int {
+		// lineStarts is sorted; find the last start <= offset.
+		lo, hi := 0, len(lineStarts)-1
+		for lo < hi {
+			mid := (lo + hi + 1) / 2
+			if lineStarts[mid] <= offset {
+				lo = mid
+			} else {
+				hi = mid - 1
+			}
+		}
+		return lo + 1 // 1-indexed
+	}
+
+	descs := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		from, to := lineOf(r.start), lineOf(r.end)
+		if from == to {
+			descs = append(descs, fmt.Sprintf("line %d", from))
+		} else {
+			descs = append(descs, fmt.Sprintf("lines %d–%d", from, to))
+		}
+	}
+
+	joined := descs[0]
+	for _, d := range descs[1:] {
+		joined += " and " + d
+	}
+	return fmt.Sprintf("%s changed externally; re-read these ranges before editing", joined)
+}
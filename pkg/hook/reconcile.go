@@ -0,0 +1,235 @@
+// Reconcile performs a three-way merge between the snapshot Claude last saw
+// (base), the file as it actually is on disk now (ours, i.e. the external
+// edit), and Claude's pending edit applied to base (theirs). Non-conflicting
+// changes are merged automatically; overlapping changes are surfaced as a
+// structured conflict instead of silently picking a side.
+package hook
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sort"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+)
+
+// editOverlapsExternalChange reports whether the lines Claude's pending edit
+// targets (oldString, as it appeared in the snapshot) fall within the lines
+// that changed externally.
+func editOverlapsExternalChange(s store.Store, storedHash string, current []byte, oldString string) bool {
+	base, err := loadSnapshot(s, storedHash)
+	if err != nil {
+		return false
+	}
+
+	idx := bytes.Index(base, []byte(oldString))
+	if idx < 0 {
+		return false // can't localize the pending edit in the snapshot
+	}
+	editStartLine := bytes.Count(base[:idx], []byte("\n"))
+	editEndLine := bytes.Count(base[:idx+len(oldString)], []byte("\n"))
+
+	for _, op := range diffLines(splitLines(base), splitLines(current)) {
+		if op.tag == opEqual {
+			continue
+		}
+		if op.aStart <= editEndLine && editStartLine <= op.aEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeConflict describes one region where the external edit and Claude's
+// pending edit both touched overlapping lines.
+type MergeConflict struct {
+	BaseStartLine int    `json:"base_start_line"`
+	BaseEndLine   int    `json:"base_end_line"`
+	Ours          string `json:"ours"`
+	Theirs        string `json:"theirs"`
+}
+
+// ReconcileResult is Reconcile's outcome: either cleanly Approved with
+// Merged content, or blocked with the Conflicts that need manual resolution.
+type ReconcileResult struct {
+	Approved  bool            `json:"approved"`
+	Merged    string          `json:"merged,omitempty"`
+	Conflicts []MergeConflict `json:"conflicts,omitempty"`
+	Reason    string          `json:"reason,omitempty"`
+}
+
+var errNoPendingEdit = errors.New("no pending edit (old_string) supplied")
+
+// Reconcile merges the external change to filePath against Claude's pending
+// edit (toolInput.OldString/NewString), using the snapshot stored under s as
+// the merge base.
+func Reconcile(s store.Store, filePath string, toolInput ToolInput) (ReconcileResult, error) {
+	current, err := os.ReadFile(filePath)
+	if err != nil {
+		return ReconcileResult{}, err
+	}
+
+	storedHash, err := s.Get(filePath)
+	if errors.Is(err, store.ErrNotFound) {
+		return ReconcileResult{Approved: true, Reason: "no prior snapshot, nothing to reconcile"}, nil
+	}
+	if err != nil {
+		return ReconcileResult{}, err
+	}
+
+	base, err := loadSnapshot(s, storedHash)
+	if err != nil {
+		return ReconcileResult{Approved: false, Reason: "no snapshot recorded for this file"}, nil
+	}
+
+	if toolInput.OldString == "" {
+		return ReconcileResult{}, errNoPendingEdit
+	}
+	theirs := bytes.Replace(base, []byte(toolInput.OldString), []byte(toolInput.NewString), 1)
+
+	merged, conflicts := merge3(splitLines(base), splitLines(current), splitLines(theirs))
+	result := ReconcileResult{
+		Approved:  len(conflicts) == 0,
+		Merged:    joinLines(merged),
+		Conflicts: conflicts,
+	}
+	if !result.Approved {
+		result.Reason = "external edit overlaps the pending edit; manual resolution required"
+	}
+	return result, nil
+}
+
+// taggedOp is one non-equal opcode from either side of the three-way merge,
+// kept alongside which side it came from so overlapping ours/theirs hunks
+// can be clustered and resolved together.
+type taggedOp struct {
+	op   diffOp
+	side byte // 'o' (ours) or 't' (theirs)
+}
+
+// merge3 applies both the base->ours and base->theirs diffs on top of base.
+// Hunks are clustered by base-line-range overlap (not by coincident start
+// index, since one hunk may start inside another): a cluster touched by
+// only one side is applied automatically, and a cluster touched by both
+// sides is reported as a conflict (keeping "ours" in the merged output
+// pending manual resolution) unless both sides made the identical change.
+func merge3(base, ours, theirs []string) (merged []string, conflicts []MergeConflict) {
+	oursOps := nonEqualOps(diffLines(base, ours))
+	theirsOps := nonEqualOps(diffLines(base, theirs))
+
+	var all []taggedOp
+	for _, op := range oursOps {
+		all = append(all, taggedOp{op, 'o'})
+	}
+	for _, op := range theirsOps {
+		all = append(all, taggedOp{op, 't'})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].op.aStart < all[j].op.aStart })
+
+	i := 0
+	for idx := 0; idx < len(all); {
+		clusterStart := all[idx].op.aStart
+		clusterEnd := all[idx].op.aEnd
+		j := idx + 1
+		for j < len(all) && all[j].op.aStart < clusterEnd {
+			if all[j].op.aEnd > clusterEnd {
+				clusterEnd = all[j].op.aEnd
+			}
+			j++
+		}
+		cluster := all[idx:j]
+		idx = j
+
+		if clusterStart > i {
+			merged = append(merged, base[i:clusterStart]...)
+		}
+
+		var oOps, tOps []diffOp
+		for _, c := range cluster {
+			if c.side == 'o' {
+				oOps = append(oOps, c.op)
+			} else {
+				tOps = append(tOps, c.op)
+			}
+		}
+
+		switch {
+		case len(tOps) == 0:
+			merged = append(merged, renderRange(base, ours, oOps, clusterStart, clusterEnd)...)
+		case len(oOps) == 0:
+			merged = append(merged, renderRange(base, theirs, tOps, clusterStart, clusterEnd)...)
+		default: // both sides touched this region
+			oursText := renderRange(base, ours, oOps, clusterStart, clusterEnd)
+			theirsText := renderRange(base, theirs, tOps, clusterStart, clusterEnd)
+			if stringSlicesEqual(oursText, theirsText) {
+				merged = append(merged, oursText...)
+			} else {
+				conflicts = append(conflicts, MergeConflict{
+					BaseStartLine: clusterStart + 1,
+					BaseEndLine:   clusterEnd,
+					Ours:          joinLines(oursText),
+					Theirs:        joinLines(theirsText),
+				})
+				merged = append(merged, oursText...) // keep the on-disk version pending manual resolution
+			}
+		}
+		i = clusterEnd
+	}
+	if i < len(base) {
+		merged = append(merged, base[i:]...)
+	}
+	return merged, conflicts
+}
+
+// renderRange reconstructs one side's text for base[start:end], applying
+// that side's ops (disjoint, sorted, all fully contained within the range)
+// and keeping base lines the side's ops didn't touch.
+func renderRange(base, sideLines []string, ops []diffOp, start, end int) []string {
+	var out []string
+	i := start
+	for _, op := range ops {
+		if op.aStart > i {
+			out = append(out, base[i:op.aStart]...)
+		}
+		out = append(out, sideLines[op.bStart:op.bEnd]...)
+		i = op.aEnd
+	}
+	if i < end {
+		out = append(out, base[i:end]...)
+	}
+	return out
+}
+
+func nonEqualOps(ops []diffOp) []diffOp {
+	var out []diffOp
+	for _, op := range ops {
+		if op.tag != opEqual {
+			out = append(out, op)
+		}
+	}
+	return out
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func joinLines(lines []string) string {
+	out := ""
+	for i, l := range lines {
+		if i > 0 {
+			out += "\n"
+		}
+		out += l
+	}
+	return out
+}
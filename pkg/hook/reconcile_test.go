@@ -0,0 +1,47 @@
+package hook
+
+import "testing"
+
+func TestMerge3NonOverlappingChangesApplyCleanly(t *testing.T) {
+	base := []string{"one", "two", "three", "four", "five"}
+	ours := []string{"one", "TWO", "three", "four", "five"}   // external edit changed line 2
+	theirs := []string{"one", "two", "three", "four", "FIVE"} // Claude's pending edit changes line 5
+
+	merged, conflicts := merge3(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %+v", conflicts)
+	}
+	want := []string{"one", "TWO", "three", "four", "FIVE"}
+	if !stringSlicesEqual(merged, want) {
+		t.Errorf("merged = %v, want %v", merged, want)
+	}
+}
+
+func TestMerge3OverlappingChangesConflict(t *testing.T) {
+	base := []string{"one", "two", "three"}
+	ours := []string{"one", "TWO-EXTERNAL", "three"}
+	theirs := []string{"one", "TWO-CLAUDE", "three"}
+
+	_, conflicts := merge3(base, ours, theirs)
+	if len(conflicts) != 1 {
+		t.Fatalf("expected exactly one conflict, got %d", len(conflicts))
+	}
+	if conflicts[0].Ours != "TWO-EXTERNAL" || conflicts[0].Theirs != "TWO-CLAUDE" {
+		t.Errorf("unexpected conflict content: %+v", conflicts[0])
+	}
+}
+
+// TestMerge3StaggeredOverlapConflict covers hunks that overlap but don't
+// start on the same base line: ours replaces B,C and theirs (independently)
+// replaces C,D. The shared line C means these must conflict rather than
+// silently applying one side and discarding the other.
+func TestMerge3StaggeredOverlapConflict(t *testing.T) {
+	base := []string{"A", "B", "C", "D"}
+	ours := []string{"A", "X", "D"}   // replaces B,C with X
+	theirs := []string{"A", "B", "Y"} // replaces C,D with Y
+
+	_, conflicts := merge3(base, ours, theirs)
+	if len(conflicts) != 1 {
+		t.Fatalf("expected exactly one conflict, got %d: %+v", len(conflicts), conflicts)
+	}
+}
@@ -0,0 +1,78 @@
+// Content-addressed, zstd-compressed snapshots of the file contents Claude
+// last saw, held behind the store.Store interface so every backend gets
+// reconciliation for free. Verify uses these to build a real diff against
+// the current on-disk file instead of just reporting "stale"; Reconcile
+// uses them as the merge base.
+package hook
+
+import (
+	"bytes"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+	"github.com/klauspost/compress/zstd"
+)
+
+func compress(data []byte) ([]byte, error) {
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer enc.Close()
+	return enc.EncodeAll(data, nil), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+	return dec.DecodeAll(data, nil)
+}
+
+// storeSnapshot compresses data and writes it to s under the content
+// address hash.
+func storeSnapshot(s store.Store, hash string, data []byte) error {
+	compressed, err := compress(data)
+	if err != nil {
+		return err
+	}
+	return s.PutSnapshot(hash, compressed)
+}
+
+// loadSnapshot reads back and decompresses the snapshot stored under hash.
+func loadSnapshot(s store.Store, hash string) ([]byte, error) {
+	compressed, err := s.GetSnapshot(hash)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(compressed)
+}
+
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	var lines []string
+	for len(data) > 0 {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			lines = append(lines, string(data))
+			break
+		}
+		lines = append(lines, string(data[:idx]))
+		data = data[idx+1:]
+	}
+	return lines
+}
+
+// snapshotDiff builds a unified diff between the snapshot stored under
+// storedHash and the file's current contents, for inclusion in a stale
+// Verify response. Returns "" if there's no snapshot to diff against.
+func snapshotDiff(s store.Store, storedHash string, current []byte) string {
+	snapshot, err := loadSnapshot(s, storedHash)
+	if err != nil {
+		return ""
+	}
+	return unifiedDiff("last seen by Claude", "on disk now", splitLines(snapshot), splitLines(current), 3)
+}
@@ -0,0 +1,126 @@
+package hook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/hash"
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+)
+
+// CompareStatus computes the current hash of filePath and compares it
+// against what's recorded in s, for access logging. Returns "match",
+// "mismatch", "new" (no prior record), or "error", along with the current
+// and last-stored hashes (storedHash is "" for "new" and "error").
+func CompareStatus(s store.Store, filePath string) (status, currentHash, storedHash string) {
+	current, err := hash.Checksum(filePath)
+	if err != nil {
+		return "error", "", ""
+	}
+	return CompareStatusWithHash(s, filePath, current)
+}
+
+// CompareStatusWithHash is CompareStatus for a caller that already knows
+// filePath's current hash (e.g. the daemon's file cache), skipping the
+// redundant re-hash from disk.
+func CompareStatusWithHash(s store.Store, filePath, current string) (status, currentHash, storedHash string) {
+	stored, err := s.Get(filePath)
+	if errors.Is(err, store.ErrNotFound) {
+		return "new", current, ""
+	}
+	if err != nil {
+		return "error", current, ""
+	}
+
+	if stored == current {
+		return "match", current, stored
+	}
+	return "mismatch", current, stored
+}
+
+// Store records filePath's current hash, chunk manifest, and a compressed
+// snapshot of its contents, so a later Verify can detect external changes
+// and report what changed.
+func Store(s store.Store, filePath string) error {
+	data := readFileAllowMissing(filePath)
+	if data == nil {
+		return nil // file doesn't exist, nothing to store
+	}
+
+	sum := hash.Sum(data)
+
+	if err := s.PutManifest(filePath, buildManifest(data, sum)); err != nil {
+		return err
+	}
+	if err := storeSnapshot(s, sum, data); err != nil {
+		return err
+	}
+	return s.Put(filePath, sum)
+}
+
+// Verify reports whether filePath has changed externally since the last
+// Store call. When blocked, reason explains what changed (falling back to a
+// whole-file message if no chunk manifest is available), diff is a unified
+// diff against the last-seen snapshot (if any), and overlapsPendingEdit
+// reports whether the external change intersects toolInput's pending edit.
+func Verify(s store.Store, filePath string, toolInput ToolInput) (blocked bool, reason, diff string, overlapsPendingEdit bool) {
+	data := readFileAllowMissing(filePath)
+	if data == nil {
+		return false, "", "", false // new or unreadable file; the tool call will fail/succeed on its own
+	}
+
+	stored, err := s.Get(filePath)
+	if err != nil {
+		return false, "", "", false // never read before, allow
+	}
+
+	current := hash.Sum(data)
+	if stored == current {
+		return false, "", "", false
+	}
+
+	reason = fmt.Sprintf(
+		"STALE FILE: %s has been modified externally since it was last read. Re-read the file before editing.",
+		filepath.Base(filePath),
+	)
+	if manifest, err := s.GetManifest(filePath); err == nil {
+		if ranges := changedRanges(manifest, data); len(ranges) > 0 {
+			if detail := lineRangesReason(data, ranges); detail != "" {
+				reason = fmt.Sprintf("STALE FILE: %s — %s", filepath.Base(filePath), detail)
+			}
+		}
+	}
+
+	diff = snapshotDiff(s, stored, data)
+	if toolInput.OldString != "" {
+		overlapsPendingEdit = editOverlapsExternalChange(s, stored, data, toolInput.OldString)
+	}
+	return true, reason, diff, overlapsPendingEdit
+}
+
+// readFileAllowMissing reads filePath, returning nil for a missing or
+// unreadable file rather than an error — the tool call itself will succeed
+// or fail reading it, so the hook doesn't need to.
+func readFileAllowMissing(filePath string) []byte {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// LogEntry is a convenience wrapper around store.LogEntry that stamps the
+// current time, matching how every CLI/daemon call site logs an access.
+func LogEntry(action, tool, status, currentHash, storedHash string) store.LogEntry {
+	return store.LogEntry{
+		Time:        time.Now(),
+		Action:      action,
+		Tool:        tool,
+		Status:      status,
+		CurrentHash: currentHash,
+		StoredHash:  storedHash,
+	}
+}
@@ -0,0 +1,57 @@
+package hook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store/memstore"
+)
+
+func TestStoreThenVerifyMatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "f.txt")
+	os.WriteFile(path, []byte("hello\n"), 0644)
+
+	s := memstore.New()
+	if err := Store(s, path); err != nil {
+		t.Fatal(err)
+	}
+
+	blocked, _, _, _ := Verify(s, path, ToolInput{})
+	if blocked {
+		t.Error("expected verify to allow an unchanged file")
+	}
+}
+
+func TestVerifyBlocksExternalChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "f.txt")
+	os.WriteFile(path, []byte("hello\n"), 0644)
+
+	s := memstore.New()
+	if err := Store(s, path); err != nil {
+		t.Fatal(err)
+	}
+
+	os.WriteFile(path, []byte("hello, externally modified\n"), 0644)
+
+	blocked, reason, _, _ := Verify(s, path, ToolInput{})
+	if !blocked {
+		t.Fatal("expected verify to block an externally modified file")
+	}
+	if reason == "" {
+		t.Error("expected a non-empty reason")
+	}
+}
+
+func TestVerifyAllowsNeverStoredFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "new.txt")
+	os.WriteFile(path, []byte("brand new\n"), 0644)
+
+	blocked, _, _, _ := Verify(memstore.New(), path, ToolInput{})
+	if blocked {
+		t.Error("expected verify to allow a file with no prior record")
+	}
+}
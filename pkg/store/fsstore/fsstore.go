@@ -0,0 +1,265 @@
+// Package fsstore is the flat-file Store backend: today's default, and the
+// one file-checksum-guard has always used under /tmp/claude-file-checksums.
+// Each tracked key (an absolute file path) is hashed to a flat filename so
+// paths containing slashes don't need directory creation; a ".path" sidecar
+// records the original key so List can enumerate tracked files.
+package fsstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/hash"
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+)
+
+// maxSnapshots bounds the content-addressed object store; PutSnapshot
+// evicts the least-recently-touched blobs once this is exceeded.
+const maxSnapshots = 500
+
+// FSStore is a store.Store backed by flat files under BaseDir.
+type FSStore struct {
+	BaseDir string
+}
+
+// New returns an FSStore rooted at baseDir. baseDir is created on first
+// write, not here.
+func New(baseDir string) *FSStore {
+	return &FSStore{BaseDir: baseDir}
+}
+
+// keyPath hashes key to a stable, filesystem-safe filename.
+func (s *FSStore) keyPath(key string) string {
+	return filepath.Join(s.BaseDir, hash.Sum([]byte(key)))
+}
+
+func (s *FSStore) pathSidecar(key string) string    { return s.keyPath(key) + ".path" }
+func (s *FSStore) manifestPath(key string) string   { return s.keyPath(key) + ".manifest.json" }
+func (s *FSStore) logPath(key string) string        { return s.keyPath(key) + ".log" }
+func (s *FSStore) objectsDir() string               { return filepath.Join(s.BaseDir, "objects") }
+func (s *FSStore) objectPath(objHash string) string { return filepath.Join(s.objectsDir(), objHash) }
+
+func (s *FSStore) Get(key string) (string, error) {
+	b, err := os.ReadFile(s.keyPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", store.ErrNotFound
+	}
+	return string(b), err
+}
+
+func (s *FSStore) Put(key, checksum string) error {
+	if err := os.MkdirAll(s.BaseDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.pathSidecar(key), []byte(key), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(s.keyPath(key), []byte(checksum), 0644)
+}
+
+func (s *FSStore) GetManifest(key string) ([]byte, error) {
+	b, err := os.ReadFile(s.manifestPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, store.ErrNotFound
+	}
+	return b, err
+}
+
+func (s *FSStore) PutManifest(key string, manifest []byte) error {
+	if manifest == nil {
+		err := os.Remove(s.manifestPath(key))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if err := os.MkdirAll(s.BaseDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.manifestPath(key), manifest, 0644)
+}
+
+func (s *FSStore) GetSnapshot(objHash string) ([]byte, error) {
+	b, err := os.ReadFile(s.objectPath(objHash))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, store.ErrNotFound
+	}
+	return b, err
+}
+
+func (s *FSStore) PutSnapshot(objHash string, data []byte) error {
+	if err := os.MkdirAll(s.objectsDir(), 0755); err != nil {
+		return err
+	}
+
+	path := s.objectPath(objHash)
+	if _, err := os.Stat(path); err == nil {
+		now := time.Now()
+		os.Chtimes(path, now, now) // touch for LRU purposes
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	return s.evictOldSnapshots()
+}
+
+func (s *FSStore) evictOldSnapshots() error {
+	entries, err := os.ReadDir(s.objectsDir())
+	if err != nil {
+		return err
+	}
+	if len(entries) <= maxSnapshots {
+		return nil
+	}
+
+	type aged struct {
+		name    string
+		modTime int64
+	}
+	items := make([]aged, 0, len(entries))
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		items = append(items, aged{name: e.Name(), modTime: info.ModTime().UnixNano()})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].modTime < items[j].modTime })
+
+	for _, a := range items[:len(items)-maxSnapshots] {
+		os.Remove(filepath.Join(s.objectsDir(), a.name))
+	}
+	return nil
+}
+
+func (s *FSStore) Log(key string, entry store.LogEntry) error {
+	if err := os.MkdirAll(s.BaseDir, 0755); err != nil {
+		return err
+	}
+
+	line := fmt.Sprintf("%s  %-6s  tool=%s  hash=%s  file=%s\n",
+		entry.Time.Format(time.RFC3339), entry.Action, entry.Tool, entry.Status, key)
+	f, err := os.OpenFile(s.logPath(key), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(line); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return s.appendEvent(key, entry)
+}
+
+func (s *FSStore) eventsPath() string { return filepath.Join(s.BaseDir, "events.jsonl") }
+
+// appendEvent records entry as a JSON line in the shared events.jsonl
+// sidecar, which Events reads back. Unlike the per-key .log file above
+// (kept as-is for humans grepping one file's history), this spans every
+// tracked file and is what the `serve` web UI and JSON API query.
+func (s *FSStore) appendEvent(key string, entry store.LogEntry) error {
+	b, err := json.Marshal(store.Event{LogEntry: entry, Path: key})
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.eventsPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// Events reads back every event appended via Log, across all tracked
+// files. A malformed or truncated trailing line (e.g. from a process
+// killed mid-write) is skipped rather than failing the whole read.
+func (s *FSStore) Events() ([]store.Event, error) {
+	b, err := os.ReadFile(s.eventsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var events []store.Event
+	for _, line := range bytes.Split(b, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var e store.Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// MigrateTo copies every checksum, manifest, and snapshot this FSStore knows
+// about into dst. It's meant for a one-time switch to a different backend
+// (e.g. FCG_STORE=sqlite://...): run it once, then point the CLI at dst.
+// Only entries written since FSStore started recording a ".path" sidecar
+// (see Put) are enumerable — older, pre-upgrade flat-file checksums have no
+// recorded original path and can't be recovered.
+func (s *FSStore) MigrateTo(dst store.Store) error {
+	keys, err := s.List()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		checksum, err := s.Get(key)
+		if err != nil {
+			continue
+		}
+		if err := dst.Put(key, checksum); err != nil {
+			return fmt.Errorf("migrate %s: %w", key, err)
+		}
+		if manifest, err := s.GetManifest(key); err == nil {
+			if err := dst.PutManifest(key, manifest); err != nil {
+				return fmt.Errorf("migrate manifest %s: %w", key, err)
+			}
+			if objHash := checksum; objHash != "" {
+				if snap, err := s.GetSnapshot(objHash); err == nil {
+					dst.PutSnapshot(objHash, snap)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *FSStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.BaseDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".path") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(s.BaseDir, e.Name()))
+		if err != nil {
+			continue
+		}
+		keys = append(keys, string(b))
+	}
+	return keys, nil
+}
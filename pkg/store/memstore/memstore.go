@@ -0,0 +1,116 @@
+// Package memstore is an in-memory store.Store, used for tests that don't
+// want to touch the filesystem.
+package memstore
+
+import (
+	"sync"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+)
+
+// MemStore is a store.Store backed entirely by in-memory maps. Nothing is
+// persisted across process restarts.
+type MemStore struct {
+	mu        sync.Mutex
+	checksums map[string]string
+	manifests map[string][]byte
+	snapshots map[string][]byte
+	logs      map[string][]store.LogEntry
+	events    []store.Event
+}
+
+// New returns an empty MemStore.
+func New() *MemStore {
+	return &MemStore{
+		checksums: make(map[string]string),
+		manifests: make(map[string][]byte),
+		snapshots: make(map[string][]byte),
+		logs:      make(map[string][]store.LogEntry),
+	}
+}
+
+func (s *MemStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.checksums[key]
+	if !ok {
+		return "", store.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *MemStore) Put(key, checksum string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checksums[key] = checksum
+	return nil
+}
+
+func (s *MemStore) GetManifest(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.manifests[key]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *MemStore) PutManifest(key string, manifest []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if manifest == nil {
+		delete(s.manifests, key)
+		return nil
+	}
+	s.manifests[key] = manifest
+	return nil
+}
+
+func (s *MemStore) GetSnapshot(hash string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.snapshots[hash]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *MemStore) PutSnapshot(hash string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots[hash] = data
+	return nil
+}
+
+func (s *MemStore) Log(key string, entry store.LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logs[key] = append(s.logs[key], entry)
+	s.events = append(s.events, store.Event{LogEntry: entry, Path: key})
+	return nil
+}
+
+// Logs returns the access-log entries recorded for key, for use in tests.
+func (s *MemStore) Logs(key string) []store.LogEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]store.LogEntry(nil), s.logs[key]...)
+}
+
+func (s *MemStore) Events() ([]store.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]store.Event(nil), s.events...), nil
+}
+
+func (s *MemStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.checksums))
+	for k := range s.checksums {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
@@ -0,0 +1,195 @@
+// Package sqlitestore is a store.Store backed by a single SQLite file,
+// keeping a project's entire checksum/manifest/snapshot/log history queryable
+// in one place instead of scattered across a flat-file directory.
+package sqlitestore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite" // pure-Go driver, registers "sqlite"
+
+	"github.com/Konseyy/claude-code-file-checksum-guard/pkg/store"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS checksums (key TEXT PRIMARY KEY, checksum TEXT NOT NULL);
+CREATE TABLE IF NOT EXISTS manifests (key TEXT PRIMARY KEY, manifest BLOB NOT NULL);
+CREATE TABLE IF NOT EXISTS snapshots (hash TEXT PRIMARY KEY, data BLOB NOT NULL);
+CREATE TABLE IF NOT EXISTS logs (
+	key TEXT NOT NULL,
+	ts TEXT NOT NULL,
+	action TEXT NOT NULL,
+	tool TEXT NOT NULL,
+	status TEXT NOT NULL,
+	current_hash TEXT NOT NULL DEFAULT '',
+	stored_hash TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS logs_key_idx ON logs(key);
+`
+
+// SQLiteStore is a store.Store backed by a single SQLite database file.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and
+// ensures its schema exists.
+func Open(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := migrateLogsTable(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// migrateLogsTable adds columns the logs table gained after it was first
+// created; CREATE TABLE IF NOT EXISTS above is a no-op against an
+// already-existing table, so a database from before current_hash/stored_hash
+// were added needs them backfilled explicitly.
+func migrateLogsTable(db *sql.DB) error {
+	rows, err := db.Query(`PRAGMA table_info(logs)`)
+	if err != nil {
+		return err
+	}
+	have := make(map[string]bool)
+	for rows.Next() {
+		var cid int
+		var name, colType string
+		var notNull, pk int
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		have[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, col := range []string{"current_hash", "stored_hash"} {
+		if have[col] {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf(`ALTER TABLE logs ADD COLUMN %s TEXT NOT NULL DEFAULT ''`, col)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying database connection.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) Get(key string) (string, error) {
+	var checksum string
+	err := s.db.QueryRow(`SELECT checksum FROM checksums WHERE key = ?`, key).Scan(&checksum)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", store.ErrNotFound
+	}
+	return checksum, err
+}
+
+func (s *SQLiteStore) Put(key, checksum string) error {
+	_, err := s.db.Exec(`INSERT INTO checksums(key, checksum) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET checksum = excluded.checksum`, key, checksum)
+	return err
+}
+
+func (s *SQLiteStore) GetManifest(key string) ([]byte, error) {
+	var manifest []byte
+	err := s.db.QueryRow(`SELECT manifest FROM manifests WHERE key = ?`, key).Scan(&manifest)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrNotFound
+	}
+	return manifest, err
+}
+
+func (s *SQLiteStore) PutManifest(key string, manifest []byte) error {
+	if manifest == nil {
+		_, err := s.db.Exec(`DELETE FROM manifests WHERE key = ?`, key)
+		return err
+	}
+	_, err := s.db.Exec(`INSERT INTO manifests(key, manifest) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET manifest = excluded.manifest`, key, manifest)
+	return err
+}
+
+func (s *SQLiteStore) GetSnapshot(hash string) ([]byte, error) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT data FROM snapshots WHERE hash = ?`, hash).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrNotFound
+	}
+	return data, err
+}
+
+func (s *SQLiteStore) PutSnapshot(hash string, data []byte) error {
+	_, err := s.db.Exec(`INSERT INTO snapshots(hash, data) VALUES (?, ?)
+		ON CONFLICT(hash) DO NOTHING`, hash, data)
+	return err
+}
+
+func (s *SQLiteStore) Log(key string, entry store.LogEntry) error {
+	_, err := s.db.Exec(
+		`INSERT INTO logs(key, ts, action, tool, status, current_hash, stored_hash) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		key, entry.Time.Format(time.RFC3339), entry.Action, entry.Tool, entry.Status, entry.CurrentHash, entry.StoredHash)
+	return err
+}
+
+// Events returns every access-log entry recorded across all tracked files,
+// most recent first, for the `serve` web UI and JSON API.
+func (s *SQLiteStore) Events() ([]store.Event, error) {
+	rows, err := s.db.Query(`SELECT key, ts, action, tool, status, current_hash, stored_hash FROM logs ORDER BY ts DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []store.Event
+	for rows.Next() {
+		var e store.Event
+		var ts string
+		if err := rows.Scan(&e.Path, &ts, &e.Action, &e.Tool, &e.Status, &e.CurrentHash, &e.StoredHash); err != nil {
+			return nil, err
+		}
+		e.Time, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func (s *SQLiteStore) List() ([]string, error) {
+	rows, err := s.db.Query(`SELECT key FROM checksums`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
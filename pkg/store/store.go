@@ -0,0 +1,68 @@
+// Package store defines the persistence backend for file-checksum-guard:
+// the last-known hash, chunk manifest, and access log for each tracked
+// file, plus the content-addressed snapshot blobs used for reconciliation.
+// Callers key everything by the tracked file's absolute path; it's up to
+// each implementation to map that onto however it actually stores data
+// (a flat file named after the path's hash, a SQLite row, an in-memory map).
+package store
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Get, GetManifest, and GetSnapshot when there's
+// no record for the given key.
+var ErrNotFound = errors.New("store: not found")
+
+// LogEntry is one structured record of a store/verify access.
+type LogEntry struct {
+	Time        time.Time `json:"ts"`
+	Action      string    `json:"action"` // "store" or "verify"
+	Tool        string    `json:"tool"`
+	Status      string    `json:"status"` // "match", "mismatch", "new", or "error"
+	CurrentHash string    `json:"current_hash,omitempty"`
+	StoredHash  string    `json:"stored_hash,omitempty"`
+}
+
+// Event is one LogEntry annotated with the file path it was recorded
+// against, as returned by Events across every tracked file.
+type Event struct {
+	LogEntry
+	Path string `json:"path"`
+}
+
+// Store is the persistence backend file-checksum-guard's hook logic runs
+// against. Implementations live in subpackages (fsstore, memstore,
+// sqlitestore); the CLI picks one via FCG_STORE or a config file.
+type Store interface {
+	// Get returns the last-known checksum for key, or ErrNotFound.
+	Get(key string) (string, error)
+	// Put records checksum as the latest known hash for key.
+	Put(key, checksum string) error
+
+	// GetManifest returns the chunk manifest last stored for key, or
+	// ErrNotFound. manifest is an opaque JSON blob (see pkg/hash).
+	GetManifest(key string) ([]byte, error)
+	// PutManifest stores (or, with a nil manifest, clears) the chunk
+	// manifest for key.
+	PutManifest(key string, manifest []byte) error
+
+	// GetSnapshot returns the content-addressed blob for hash, or
+	// ErrNotFound.
+	GetSnapshot(hash string) ([]byte, error)
+	// PutSnapshot stores data under the content address hash. Implementations
+	// may deduplicate (the same hash always maps to the same content) and
+	// evict old snapshots once a backend-specific capacity is exceeded.
+	PutSnapshot(hash string, data []byte) error
+
+	// Log appends an access-log entry for key.
+	Log(key string, entry LogEntry) error
+
+	// Events returns every access-log entry recorded across all tracked
+	// files, for the `serve` web UI and JSON API.
+	Events() ([]Event, error)
+
+	// List returns the file paths currently tracked by the store.
+	List() ([]string, error)
+}